@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/le-arch/mtn-momo/pkg/campay"
+	"golang.org/x/time/rate"
+)
+
+const (
+	maxSendRetries = 3
+	initialBackoff = 500 * time.Millisecond
+	maxBackoff     = 8 * time.Second
+
+	// inFlightTimeout bounds a disbursement call that is already underway
+	// when SIGINT fires. It runs to completion on an uncancelled context
+	// instead of being aborted, so a request that actually succeeds on the
+	// wallet side isn't misreported as FAILED.
+	inFlightTimeout = 30 * time.Second
+)
+
+// runBatch disburses rows through a pool of concurrency workers, rate
+// limited to rps requests per second. On context cancellation (SIGINT) it
+// stops handing out new rows, waits for in-flight ones to finish, and
+// returns whatever results were collected so far.
+func runBatch(ctx context.Context, client *campay.Client, rows []Row, concurrency int, rps float64) []Result {
+	limiter := rate.NewLimiter(rate.Limit(rps), 1)
+
+	in := make(chan Row)
+	out := make(chan Result, len(rows))
+
+	var workers sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for row := range in {
+				out <- processRow(ctx, client, limiter, row)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(in)
+		for _, row := range rows {
+			select {
+			case <-ctx.Done():
+				return
+			case in <- row:
+			}
+		}
+	}()
+
+	go func() {
+		workers.Wait()
+		close(out)
+	}()
+
+	var results []Result
+	for res := range out {
+		results = append(results, res)
+	}
+
+	return results
+}
+
+// processRow waits for rate limiter headroom, submits the disbursement
+// with retry, and maps the outcome to a Result.
+func processRow(ctx context.Context, client *campay.Client, limiter *rate.Limiter, row Row) Result {
+	if err := limiter.Wait(ctx); err != nil {
+		// Distinct from a Campay-reported "PENDING" disbursement: this row
+		// never reached Campay at all, it was cancelled before dispatch.
+		return Result{Status: "CANCELLED", Error: err.Error()}
+	}
+
+	req := &campay.DisbursementRequest{
+		Amount:            row.Amount,
+		To:                row.Phone,
+		Description:       row.Description,
+		ExternalReference: row.ExternalReference,
+	}
+
+	resp, err := sendWithRetry(ctx, client, limiter, req)
+	if err != nil {
+		return Result{Status: "FAILED", Error: err.Error()}
+	}
+
+	return Result{Reference: resp.Reference, Status: resp.Status}
+}
+
+// sendWithRetry calls client.Disbursement.Send, retrying with exponential
+// backoff on 5xx responses and network errors. Each retry also waits for
+// limiter headroom, so retries count against the same request rate.
+func sendWithRetry(ctx context.Context, client *campay.Client, limiter *rate.Limiter, req *campay.DisbursementRequest) (*campay.DisbursementResponse, error) {
+	backoff := initialBackoff
+	var lastErr error
+
+	for attempt := 0; attempt <= maxSendRetries; attempt++ {
+		resp, err := sendOnce(ctx, client, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		lastErr = err
+		if !isRetryable(err) || attempt == maxSendRetries {
+			return nil, lastErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, lastErr
+}
+
+// sendOnce issues a single disbursement call on a context that only gates
+// whether the call is *started*: SIGINT cancelling ctx stops new attempts
+// from starting, but does not abort one already in flight. The detached
+// call context carries its own timeout so a connection that never responds
+// still can't hang the batch forever.
+func sendOnce(ctx context.Context, client *campay.Client, req *campay.DisbursementRequest) (*campay.DisbursementResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	callCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), inFlightTimeout)
+	defer cancel()
+
+	return client.Disbursement.Send(callCtx, req)
+}
+
+// isRetryable reports whether err is worth retrying: a 5xx APIError, or
+// any other error (treated as a network-level failure).
+func isRetryable(err error) bool {
+	var apiErr *campay.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	return true
+}
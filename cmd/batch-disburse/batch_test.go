@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/le-arch/mtn-momo/pkg/campay"
+	"golang.org/x/time/rate"
+)
+
+// TestRunBatchLetsInFlightRequestFinish reproduces the scenario where SIGINT
+// fires while a disbursement is slow but about to succeed: the in-flight
+// call must be allowed to complete rather than being reported FAILED with a
+// canceled error.
+func TestRunBatchLetsInFlightRequestFinish(t *testing.T) {
+	started := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"reference":"ref-1","status":"SUCCESSFUL"}`)
+	}))
+	defer srv.Close()
+
+	client := campay.New(campay.WithBaseURL(srv.URL), campay.WithAPIKey("k"))
+	rows := []Row{{Phone: "670000001", Amount: "1000"}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	resultsCh := make(chan []Result, 1)
+	go func() {
+		resultsCh <- runBatch(ctx, client, rows, 1, 100)
+	}()
+
+	<-started
+	cancel()
+
+	select {
+	case results := <-resultsCh:
+		if len(results) != 1 {
+			t.Fatalf("expected 1 result, got %d", len(results))
+		}
+		if results[0].Status != "SUCCESSFUL" || results[0].Error != "" {
+			t.Fatalf("expected the in-flight request to complete successfully, got %+v", results[0])
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("runBatch did not return")
+	}
+}
+
+// TestProcessRowCancelledDuringLimiterWaitIsDistinctFromPending ensures a
+// row cancelled before it ever reached Campay gets a Status that isn't
+// "PENDING" — that value is reserved for a Campay-reported in-flight
+// disbursement, and printSummary checks Status before Error, so the two
+// must stay distinguishable or the CSV and the on-screen summary disagree.
+func TestProcessRowCancelledDuringLimiterWaitIsDistinctFromPending(t *testing.T) {
+	client := campay.New(campay.WithBaseURL("http://unused.invalid"), campay.WithAPIKey("k"))
+
+	limiter := rate.NewLimiter(1, 1)
+	limiter.Wait(context.Background()) // drain the only token
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res := processRow(ctx, client, limiter, Row{Phone: "670000001", Amount: "1000"})
+
+	if res.Status != "CANCELLED" {
+		t.Fatalf("expected Status CANCELLED, got %q", res.Status)
+	}
+	if res.Error == "" {
+		t.Fatal("expected a non-empty Error")
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Row is one disbursement to perform, read from the input CSV.
+type Row struct {
+	Phone             string
+	Amount            string
+	Description       string
+	ExternalReference string
+}
+
+// Result is the outcome of attempting a Row's disbursement.
+type Result struct {
+	Reference string
+	Status    string
+	Error     string
+}
+
+var csvHeader = []string{"phone", "amount", "description", "external_reference"}
+
+// readRows parses a CSV file with a header row of phone, amount,
+// description, external_reference into Rows.
+func readRows(path string) ([]Row, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = len(csvHeader)
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	for i, col := range csvHeader {
+		if header[i] != col {
+			return nil, fmt.Errorf("unexpected header column %d: got %q, want %q", i, header[i], col)
+		}
+	}
+
+	var rows []Row
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, Row{
+			Phone:             record[0],
+			Amount:            record[1],
+			Description:       record[2],
+			ExternalReference: record[3],
+		})
+	}
+
+	return rows, nil
+}
+
+// writeResults writes results to path as a CSV with a
+// reference,status,error header.
+func writeResults(path string, results []Result) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	if err := w.Write([]string{"reference", "status", "error"}); err != nil {
+		return err
+	}
+
+	for _, res := range results {
+		if err := w.Write([]string{res.Reference, res.Status, res.Error}); err != nil {
+			return err
+		}
+	}
+
+	return w.Error()
+}
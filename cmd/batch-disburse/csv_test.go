@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadRows(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "input.csv")
+
+	content := "phone,amount,description,external_reference\n670000001,1000,salary,ext-1\n670000002,2000,bonus,\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	rows, err := readRows(path)
+	if err != nil {
+		t.Fatalf("readRows: %v", err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if rows[0].Phone != "670000001" || rows[0].ExternalReference != "ext-1" {
+		t.Fatalf("unexpected first row: %+v", rows[0])
+	}
+	if rows[1].ExternalReference != "" {
+		t.Fatalf("expected empty external reference, got %q", rows[1].ExternalReference)
+	}
+}
+
+func TestWriteResults(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "results.csv")
+
+	results := []Result{
+		{Reference: "ref-1", Status: "SUCCESSFUL"},
+		{Status: "FAILED", Error: "boom"},
+	}
+
+	if err := writeResults(path, results); err != nil {
+		t.Fatalf("writeResults: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+
+	want := "reference,status,error\nref-1,SUCCESSFUL,\n,FAILED,boom\n"
+	if string(got) != want {
+		t.Fatalf("unexpected CSV output:\ngot:  %q\nwant: %q", string(got), want)
+	}
+}
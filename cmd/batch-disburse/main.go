@@ -0,0 +1,126 @@
+// Command batch-disburse reads a CSV of payout rows and performs bulk
+// mobile-money disbursements through the campay package, writing a result
+// CSV and a summary.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/joho/godotenv"
+	"github.com/le-arch/mtn-momo/pkg/campay"
+)
+
+const (
+	defaultConcurrency = 5
+	defaultRate        = 5.0 // requests per second
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	inputPath := flag.String("input", "", "CSV file of phone,amount,description,external_reference rows (required)")
+	outputPath := flag.String("output", "results.csv", "CSV file to write reference,status,error results to")
+	concurrency := flag.Int("concurrency", defaultConcurrency, "number of concurrent disbursement workers")
+	rps := flag.Float64("rate", defaultRate, "maximum disbursement requests per second")
+	flag.Parse()
+
+	if *inputPath == "" {
+		return fmt.Errorf("-input is required")
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return fmt.Errorf("failed to configure campay client: %w", err)
+	}
+
+	rows, err := readRows(*inputPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", *inputPath, err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	results := runBatch(ctx, client, rows, *concurrency, *rps)
+
+	if err := writeResults(*outputPath, results); err != nil {
+		return fmt.Errorf("failed to write %s: %w", *outputPath, err)
+	}
+
+	printSummary(rows, results)
+
+	return nil
+}
+
+// newClient builds a campay.Client from the same environment variables
+// the main CLI uses (API_KEY or APP_USERNAME/APP_PASSWORD, BASE_URL).
+func newClient() (*campay.Client, error) {
+	if _, err := os.Stat(".env"); err == nil {
+		if err := godotenv.Load(); err != nil {
+			return nil, fmt.Errorf("failed to load .env: %w", err)
+		}
+	}
+
+	apiKey := os.Getenv("API_KEY")
+	appUsername := os.Getenv("APP_USERNAME")
+	appPassword := os.Getenv("APP_PASSWORD")
+
+	if apiKey == "" && (appUsername == "" || appPassword == "") {
+		return nil, fmt.Errorf("either API_KEY or both APP_USERNAME and APP_PASSWORD environment variables are required")
+	}
+
+	baseURL := os.Getenv("BASE_URL")
+	if baseURL == "" {
+		baseURL = campay.DefaultBaseURL
+	}
+
+	opts := []campay.Option{campay.WithBaseURL(baseURL)}
+	if appUsername != "" && appPassword != "" {
+		opts = append(opts, campay.WithCredentials(appUsername, appPassword))
+	} else {
+		opts = append(opts, campay.WithAPIKey(apiKey))
+	}
+
+	return campay.New(opts...), nil
+}
+
+// printSummary reports how many of the submitted rows succeeded, failed,
+// were cancelled before dispatch, or are still pending, plus how many were
+// never attempted because the batch was interrupted. Status is checked
+// before Error so the counts agree with the Status column written to the
+// results CSV.
+func printSummary(rows []Row, results []Result) {
+	var succeeded, failed, cancelled, pending int
+	for _, res := range results {
+		switch {
+		case res.Status == "CANCELLED":
+			cancelled++
+		case res.Status == "PENDING":
+			pending++
+		case res.Status == "FAILED" || res.Error != "":
+			failed++
+		default:
+			succeeded++
+		}
+	}
+
+	fmt.Println("\n=== Batch Disbursement Summary ===")
+	fmt.Printf("Total:     %d\n", len(rows))
+	fmt.Printf("Submitted: %d\n", len(results))
+	fmt.Printf("Succeeded: %d\n", succeeded)
+	fmt.Printf("Failed:    %d\n", failed)
+	fmt.Printf("Cancelled: %d\n", cancelled)
+	fmt.Printf("Pending:   %d\n", pending)
+	if len(results) < len(rows) {
+		fmt.Printf("Interrupted before processing %d row(s)\n", len(rows)-len(results))
+	}
+}
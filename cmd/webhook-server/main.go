@@ -0,0 +1,51 @@
+// Command webhook-server is a minimal example of receiving Campay
+// transaction status callbacks instead of polling.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/le-arch/mtn-momo/pkg/webhook"
+)
+
+func main() {
+	if _, err := os.Stat(".env"); err == nil {
+		if err := godotenv.Load(); err != nil {
+			log.Fatalf("failed to load .env: %v", err)
+		}
+	}
+
+	secret := os.Getenv("WEBHOOK_KEY")
+	if secret == "" {
+		log.Fatal("WEBHOOK_KEY environment variable is required")
+	}
+
+	addr := os.Getenv("WEBHOOK_ADDR")
+	if addr == "" {
+		addr = ":8080"
+	}
+
+	receiver := webhook.NewReceiver(secret)
+
+	receiver.Register("SUCCESSFUL", func(_ context.Context, event webhook.WebhookEvent) error {
+		fmt.Printf("transaction %s succeeded: amount=%s operator=%s\n", event.Reference, event.Amount, event.Operator)
+		return nil
+	})
+
+	receiver.Register("FAILED", func(_ context.Context, event webhook.WebhookEvent) error {
+		fmt.Printf("transaction %s failed\n", event.Reference)
+		return nil
+	})
+
+	http.Handle("/webhooks/campay", receiver)
+
+	log.Printf("listening on %s", addr)
+	if err := http.ListenAndServe(addr, nil); err != nil {
+		log.Fatal(err)
+	}
+}
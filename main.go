@@ -3,56 +3,36 @@ package main
 import (
 	"bufio"
 	"context"
+	"flag"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/joho/godotenv"
+	"github.com/le-arch/mtn-momo/pkg/campay"
+	"github.com/le-arch/mtn-momo/pkg/store"
 )
 
 const (
-	defaultBaseURL      = "https://demo.campay.net/api"
-	pollInterval        = 3 * time.Second
-	maxPollAttempts     = 40
-	requestTimeout      = 10 * time.Second
-	minPhoneLength      = 9
-	minAmount           = 0.0
+	pollInterval          = 3 * time.Second
+	maxPollAttempts       = 40
+	minPhoneLength        = 9
+	minAmount             = 0.0
+	defaultReconcileAfter = 10 * time.Minute
 )
 
-// Config holds application configuration
+// Config holds application configuration.
 type Config struct {
 	BaseURL         string
 	APIKey          string
+	AppUsername     string
+	AppPassword     string
 	PollInterval    time.Duration
 	MaxPollAttempts int
-}
-
-// PaymentRequest represents a payment collection request
-type PaymentRequest struct {
-	Amount      string `json:"amount"`
-	From        string `json:"from"`
-	Description string `json:"description"`
-}
-
-// InitResponse represents the API response for payment initiation
-type InitResponse struct {
-	Reference string `json:"reference"`
-	Status    string `json:"status"`
-	Message   string `json:"message"`
-}
-
-// StatusResponse represents the API response for status checks
-type StatusResponse struct {
-	Status string `json:"status"`
-}
-
-// CampayClient handles all interactions with the Campay API
-type CampayClient struct {
-	client *resty.Client
-	config *Config
+	StoreDriver     string
+	StoreDSN        string
 }
 
 func main() {
@@ -63,6 +43,10 @@ func main() {
 }
 
 func run() error {
+	reconcile := flag.Bool("reconcile", false, "reissue status checks for pending transactions older than -reconcile-after, then exit (without this flag, every PENDING transaction is checked on startup before the payment prompt)")
+	reconcileAfter := flag.Duration("reconcile-after", defaultReconcileAfter, "age threshold for -reconcile")
+	flag.Parse()
+
 	// Load configuration
 	config, err := loadConfig()
 	if err != nil {
@@ -70,7 +54,22 @@ func run() error {
 	}
 
 	// Create Campay client
-	client := NewCampayClient(config)
+	client := campay.New(clientOptions(config)...)
+
+	txnStore, err := newStore(config)
+	if err != nil {
+		return fmt.Errorf("failed to open transaction store: %w", err)
+	}
+
+	if *reconcile {
+		return reconcileStale(context.Background(), client, txnStore, *reconcileAfter)
+	}
+
+	// Resume polling for any PENDING transaction left behind by a previous
+	// run (e.g. a crash mid-poll), so restarts don't silently abandon them.
+	if err := reconcileStale(context.Background(), client, txnStore, 0); err != nil {
+		return fmt.Errorf("failed to resume pending transactions: %w", err)
+	}
 
 	// Get user input
 	paymentReq, err := getUserInput()
@@ -84,25 +83,38 @@ func run() error {
 	}
 
 	fmt.Println("\n=== Payment Details ===")
-	fmt.Printf("Number: %s\nAmount: %s\nDescription: %s\n", 
+	fmt.Printf("Number: %s\nAmount: %s\nDescription: %s\n",
 		paymentReq.From, paymentReq.Amount, paymentReq.Description)
 	fmt.Println("\nSending payment request to Campay...")
 
+	ctx, cancel := context.WithTimeout(context.Background(),
+		time.Duration(config.MaxPollAttempts)*config.PollInterval)
+	defer cancel()
+
 	// Initiate payment
-	reference, err := client.InitiatePayment(paymentReq)
+	initResp, err := client.Collection.Initiate(ctx, paymentReq)
 	if err != nil {
 		return fmt.Errorf("failed to initiate payment: %w", err)
 	}
 
-	fmt.Printf("\n✓ Transaction initialized\nReference: %s\n", reference)
+	now := time.Now().UTC()
+	if err := txnStore.Save(ctx, store.Transaction{
+		Reference:   initResp.Reference,
+		Status:      "PENDING",
+		Amount:      paymentReq.Amount,
+		Phone:       paymentReq.From,
+		Description: paymentReq.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}); err != nil {
+		return fmt.Errorf("failed to persist transaction: %w", err)
+	}
+
+	fmt.Printf("\n✓ Transaction initialized\nReference: %s\n", initResp.Reference)
 	fmt.Println("Waiting for MTN Mobile Money confirmation...")
 
 	// Poll for transaction status with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 
-		time.Duration(config.MaxPollAttempts)*config.PollInterval)
-	defer cancel()
-
-	status, err := client.PollTransactionStatus(ctx, reference)
+	status, err := pollTransactionStatus(ctx, client, txnStore, initResp.Reference, config)
 	if err != nil {
 		return fmt.Errorf("failed to get transaction status: %w", err)
 	}
@@ -113,7 +125,60 @@ func run() error {
 	return nil
 }
 
-// loadConfig loads configuration from environment variables
+// newStore opens the transaction store configured via STORE_DRIVER /
+// STORE_DSN, defaulting to an in-memory store.
+func newStore(config *Config) (store.TransactionStore, error) {
+	switch config.StoreDriver {
+	case "", "memory":
+		return store.NewMemoryStore(), nil
+	case "sqlite":
+		return store.NewSQLiteStore(config.StoreDSN)
+	case "postgres":
+		return store.NewPostgresStore(config.StoreDSN)
+	default:
+		return nil, fmt.Errorf("unknown STORE_DRIVER %q (want memory, sqlite or postgres)", config.StoreDriver)
+	}
+}
+
+// reconcileStale reissues status checks for every PENDING transaction
+// older than olderThan, so polling can resume across process restarts. It
+// is run with olderThan 0 on every normal startup; with -reconcile it is
+// run once with the -reconcile-after threshold instead, and the program
+// then exits.
+func reconcileStale(ctx context.Context, client *campay.Client, txnStore store.TransactionStore, olderThan time.Duration) error {
+	pending, err := txnStore.List(ctx, store.Filter{
+		Status:        "PENDING",
+		CreatedBefore: time.Now().Add(-olderThan),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list pending transactions: %w", err)
+	}
+
+	fmt.Printf("Reconciling %d stale pending transaction(s)...\n", len(pending))
+
+	for _, txn := range pending {
+		status, err := client.Collection.Status(ctx, txn.Reference)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  %s: status check failed: %v\n", txn.Reference, err)
+			continue
+		}
+
+		if status.Status == txn.Status {
+			fmt.Printf("  %s: still %s\n", txn.Reference, status.Status)
+			continue
+		}
+
+		if err := txnStore.UpdateStatus(ctx, txn.Reference, status.Status); err != nil {
+			fmt.Fprintf(os.Stderr, "  %s: failed to persist status: %v\n", txn.Reference, err)
+			continue
+		}
+		fmt.Printf("  %s: %s -> %s\n", txn.Reference, txn.Status, status.Status)
+	}
+
+	return nil
+}
+
+// loadConfig loads configuration from environment variables.
 func loadConfig() (*Config, error) {
 	// Load .env file if it exists (ignore error if not found)
 	if _, err := os.Stat(".env"); err == nil {
@@ -123,40 +188,44 @@ func loadConfig() (*Config, error) {
 	}
 
 	apiKey := os.Getenv("API_KEY")
-	if apiKey == "" {
-		return nil, fmt.Errorf("API_KEY environment variable is required")
+	appUsername := os.Getenv("APP_USERNAME")
+	appPassword := os.Getenv("APP_PASSWORD")
+
+	if apiKey == "" && (appUsername == "" || appPassword == "") {
+		return nil, fmt.Errorf("either API_KEY or both APP_USERNAME and APP_PASSWORD environment variables are required")
 	}
 
 	baseURL := os.Getenv("BASE_URL")
 	if baseURL == "" {
-		baseURL = defaultBaseURL
+		baseURL = campay.DefaultBaseURL
 	}
 
 	return &Config{
 		BaseURL:         baseURL,
 		APIKey:          apiKey,
+		AppUsername:     appUsername,
+		AppPassword:     appPassword,
 		PollInterval:    pollInterval,
 		MaxPollAttempts: maxPollAttempts,
+		StoreDriver:     os.Getenv("STORE_DRIVER"),
+		StoreDSN:        os.Getenv("STORE_DSN"),
 	}, nil
 }
 
-// NewCampayClient creates a new Campay API client
-func NewCampayClient(config *Config) *CampayClient {
-	client := resty.New().
-		SetTimeout(requestTimeout).
-		SetRetryCount(2).
-		SetRetryWaitTime(1 * time.Second).
-		SetHeader("Authorization", "Token "+config.APIKey).
-		SetHeader("Content-Type", "application/json")
+// clientOptions builds the campay.Options for config, preferring OAuth2
+// credentials over the static API key when both are present.
+func clientOptions(config *Config) []campay.Option {
+	opts := []campay.Option{campay.WithBaseURL(config.BaseURL)}
 
-	return &CampayClient{
-		client: client,
-		config: config,
+	if config.AppUsername != "" && config.AppPassword != "" {
+		return append(opts, campay.WithCredentials(config.AppUsername, config.AppPassword))
 	}
+
+	return append(opts, campay.WithAPIKey(config.APIKey))
 }
 
-// getUserInput prompts the user for payment details
-func getUserInput() (*PaymentRequest, error) {
+// getUserInput prompts the user for payment details.
+func getUserInput() (*campay.CollectionRequest, error) {
 	reader := bufio.NewReader(os.Stdin)
 
 	fmt.Print("Enter mobile money number: ")
@@ -180,15 +249,15 @@ func getUserInput() (*PaymentRequest, error) {
 	}
 	description = strings.TrimSpace(description)
 
-	return &PaymentRequest{
+	return &campay.CollectionRequest{
 		Amount:      amount,
 		From:        momoNumber,
 		Description: description,
 	}, nil
 }
 
-// validatePaymentRequest validates the payment request fields
-func validatePaymentRequest(req *PaymentRequest) error {
+// validatePaymentRequest validates the payment request fields.
+func validatePaymentRequest(req *campay.CollectionRequest) error {
 	if err := validatePhoneNumber(req.From); err != nil {
 		return err
 	}
@@ -201,7 +270,7 @@ func validatePaymentRequest(req *PaymentRequest) error {
 	return nil
 }
 
-// validatePhoneNumber validates Cameroon phone numbers
+// validatePhoneNumber validates Cameroon phone numbers.
 func validatePhoneNumber(phone string) error {
 	if phone == "" {
 		return fmt.Errorf("phone number cannot be empty")
@@ -224,7 +293,7 @@ func validatePhoneNumber(phone string) error {
 	return nil
 }
 
-// validateAmount validates the payment amount
+// validateAmount validates the payment amount.
 func validateAmount(amount string) error {
 	if amount == "" {
 		return fmt.Errorf("amount cannot be empty")
@@ -242,7 +311,7 @@ func validateAmount(amount string) error {
 	return nil
 }
 
-// validateDescription validates the payment description
+// validateDescription validates the payment description.
 func validateDescription(description string) error {
 	if description == "" {
 		return fmt.Errorf("description cannot be empty")
@@ -253,48 +322,29 @@ func validateDescription(description string) error {
 	return nil
 }
 
-// InitiatePayment sends a payment collection request to Campay
-func (c *CampayClient) InitiatePayment(req *PaymentRequest) (string, error) {
-	var initResp InitResponse
-
-	resp, err := c.client.R().
-		SetBody(req).
-		SetResult(&initResp).
-		Post(c.config.BaseURL + "/collect/")
-
-	if err != nil {
-		return "", fmt.Errorf("request failed: %w", err)
-	}
-
-	if resp.StatusCode() < 200 || resp.StatusCode() >= 300 {
-		return "", fmt.Errorf("API error (status %d): %s", 
-			resp.StatusCode(), initResp.Message)
-	}
-
-	if initResp.Reference == "" {
-		return "", fmt.Errorf("no reference returned: %s", initResp.Message)
-	}
-
-	return initResp.Reference, nil
-}
-
-// PollTransactionStatus polls the transaction status until completion or timeout
-func (c *CampayClient) PollTransactionStatus(ctx context.Context, reference string) (string, error) {
+// pollTransactionStatus polls the transaction status until completion or
+// timeout, persisting each transition to txnStore and printing progress to
+// stdout.
+func pollTransactionStatus(ctx context.Context, client *campay.Client, txnStore store.TransactionStore, reference string, config *Config) (string, error) {
 	attempts := 0
 
-	for attempts < c.config.MaxPollAttempts {
+	for attempts < config.MaxPollAttempts {
 		select {
 		case <-ctx.Done():
 			return "", fmt.Errorf("transaction status check cancelled: %w", ctx.Err())
 		default:
 		}
 
-		status, err := c.CheckTransactionStatus(reference)
+		status, err := client.Collection.Status(ctx, reference)
 		if err != nil {
 			return "", fmt.Errorf("failed to check status: %w", err)
 		}
 
-		switch status {
+		if err := txnStore.UpdateStatus(ctx, reference, status.Status); err != nil {
+			return "", fmt.Errorf("failed to persist status: %w", err)
+		}
+
+		switch status.Status {
 		case "SUCCESSFUL":
 			return "✓ Transaction Successful", nil
 		case "FAILED":
@@ -302,35 +352,15 @@ func (c *CampayClient) PollTransactionStatus(ctx context.Context, reference stri
 		}
 
 		attempts++
-		fmt.Printf("Status: PENDING... (attempt %d/%d)\n", attempts, c.config.MaxPollAttempts)
+		fmt.Printf("Status: PENDING... (attempt %d/%d)\n", attempts, config.MaxPollAttempts)
 
 		select {
 		case <-ctx.Done():
 			return "", fmt.Errorf("transaction timeout: %w", ctx.Err())
-		case <-time.After(c.config.PollInterval):
+		case <-time.After(config.PollInterval):
 			// Continue polling
 		}
 	}
 
-	return "", fmt.Errorf("transaction timeout after %d attempts", c.config.MaxPollAttempts)
+	return "", fmt.Errorf("transaction timeout after %d attempts", config.MaxPollAttempts)
 }
-
-// CheckTransactionStatus checks the current status of a transaction
-func (c *CampayClient) CheckTransactionStatus(reference string) (string, error) {
-	var statusResp StatusResponse
-
-	resp, err := c.client.R().
-		SetResult(&statusResp).
-		Get(c.config.BaseURL + "/transaction/" + reference + "/")
-
-	if err != nil {
-		return "", fmt.Errorf("request failed: %w", err)
-	}
-
-	if resp.StatusCode() < 200 || resp.StatusCode() >= 300 {
-		return "", fmt.Errorf("API error (status %d): %s", 
-			resp.StatusCode(), resp.String())
-	}
-
-	return statusResp.Status, nil
-}
\ No newline at end of file
@@ -0,0 +1,81 @@
+package campay
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// tokenRefreshSkew is subtracted from the token's reported expiry so that
+// refreshes happen proactively, before the token actually goes stale.
+const tokenRefreshSkew = 30 * time.Second
+
+// tokenResponse is the payload returned by POST /token/.
+type tokenResponse struct {
+	Token     string `json:"token"`
+	ExpiresIn int    `json:"expires_in"`
+}
+
+// tokenManager exchanges APP_USERNAME/APP_PASSWORD credentials for a
+// short-lived bearer token and keeps it refreshed. A single tokenManager is
+// shared by all requests made through a Client, so access is mutex-guarded
+// to avoid a thundering herd of refreshes under concurrent use.
+type tokenManager struct {
+	client *Client
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func newTokenManager(c *Client) *tokenManager {
+	return &tokenManager{client: c}
+}
+
+// validToken returns a valid access token, refreshing it first if it is
+// missing or within tokenRefreshSkew of expiring.
+func (m *tokenManager) validToken(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.token != "" && time.Now().Before(m.expiresAt) {
+		return m.token, nil
+	}
+
+	return m.refreshLocked(ctx)
+}
+
+// forceRefresh discards any cached token and fetches a new one.
+func (m *tokenManager) forceRefresh(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.token = ""
+	return m.refreshLocked(ctx)
+}
+
+func (m *tokenManager) refreshLocked(ctx context.Context) (string, error) {
+	var tokenResp tokenResponse
+
+	resp, err := m.client.authHTTP.R().
+		SetContext(ctx).
+		SetBody(map[string]string{
+			"username": m.client.username,
+			"password": m.client.password,
+		}).
+		SetResult(&tokenResp).
+		Post(m.client.url("/token/"))
+	if err != nil {
+		return "", fmt.Errorf("campay: token refresh failed: %w", err)
+	}
+
+	if resp.IsError() {
+		return "", newAPIError(resp)
+	}
+
+	m.token = tokenResp.Token
+	m.expiresAt = time.Now().Add(time.Duration(tokenResp.ExpiresIn)*time.Second - tokenRefreshSkew)
+
+	return m.token, nil
+}
@@ -0,0 +1,43 @@
+package campay
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestCredentialedRequestDoesNotDeadlock guards against the token exchange
+// routing back through the auth-middleware-carrying client: since
+// OnBeforeRequest calls validToken on every request, a token refresh that
+// reused that same client would recurse into validToken and block forever
+// trying to re-lock tokenManager.mu.
+func TestCredentialedRequestDoesNotDeadlock(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token/" {
+			fmt.Fprint(w, `{"token":"t","expires_in":3600}`)
+			return
+		}
+		fmt.Fprint(w, `{"total":"100","currency":"XAF"}`)
+	}))
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL), WithCredentials("user", "pass"))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.Balance.Get(context.Background())
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("request deadlocked refreshing the OAuth2 token")
+	}
+}
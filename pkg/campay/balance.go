@@ -0,0 +1,26 @@
+package campay
+
+import "context"
+
+// BalanceService reports the merchant account's available balance.
+type BalanceService struct {
+	client *Client
+}
+
+// Balance is the merchant account balance.
+type Balance struct {
+	Total    string `json:"total"`
+	Currency string `json:"currency"`
+}
+
+// Get fetches the current account balance.
+func (s *BalanceService) Get(ctx context.Context) (*Balance, error) {
+	var balance Balance
+
+	err := s.client.do(s.client.newRequest().SetContext(ctx), "GET", "/balance/", &balance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &balance, nil
+}
@@ -0,0 +1,191 @@
+// Package campay provides a Go client for the Campay mobile money API,
+// covering collections, disbursements, transaction history and balance
+// lookups.
+package campay
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	// DefaultBaseURL is used when no base URL is supplied via WithBaseURL.
+	DefaultBaseURL = "https://demo.campay.net/api"
+
+	defaultTimeout = 10 * time.Second
+)
+
+// Logger is the minimal logging interface the client writes to. It is
+// satisfied by resty's default logger as well as most structured loggers.
+type Logger interface {
+	Errorf(format string, v ...interface{})
+	Warnf(format string, v ...interface{})
+	Debugf(format string, v ...interface{})
+}
+
+// noopLogger discards everything. It is the default when no logger is set.
+type noopLogger struct{}
+
+func (noopLogger) Errorf(string, ...interface{}) {}
+func (noopLogger) Warnf(string, ...interface{})  {}
+func (noopLogger) Debugf(string, ...interface{}) {}
+
+// Client is the entry point to the Campay API. Construct one with New and
+// an appropriate set of Options, then use the typed services (Collection,
+// Disbursement, Transaction, Balance) to make requests.
+type Client struct {
+	http     *resty.Client
+	authHTTP *resty.Client
+	baseURL  string
+	apiKey   string
+	username string
+	password string
+	logger   Logger
+
+	tokenMgr *tokenManager
+
+	Collection   *CollectionService
+	Disbursement *DisbursementService
+	Transaction  *TransactionService
+	Balance      *BalanceService
+}
+
+// Option configures a Client. Options are applied in the order they are
+// passed to New.
+type Option func(*Client)
+
+// WithBaseURL overrides the default Campay API base URL, e.g. to target the
+// production environment instead of the demo sandbox.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient lets callers supply a pre-configured *http.Client, for
+// example one wired up with custom transport-level tracing or proxying.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.http = resty.NewWithClient(httpClient)
+	}
+}
+
+// WithAPIKey sets the static API key used for the legacy Token auth mode.
+// It is ignored once OAuth2 credentials are configured via WithCredentials.
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithCredentials configures OAuth2 username/password authentication.
+// The Client exchanges these for a short-lived bearer token at
+// "{BaseURL}/token/" and refreshes it automatically before it expires.
+// When set, it takes precedence over WithAPIKey.
+func WithCredentials(username, password string) Option {
+	return func(c *Client) {
+		c.username = username
+		c.password = password
+	}
+}
+
+// WithTimeout sets the per-request timeout. Defaults to 10 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.http.SetTimeout(timeout)
+	}
+}
+
+// WithLogger sets the logger used for diagnostic output. Defaults to a
+// no-op logger.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// New creates a Client ready to make requests against the Campay API.
+func New(opts ...Option) *Client {
+	c := &Client{
+		http:    resty.New(),
+		baseURL: DefaultBaseURL,
+		logger:  noopLogger{},
+	}
+	c.http.SetTimeout(defaultTimeout)
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.http.SetHeader("Content-Type", "application/json")
+	c.http.SetLogger(c.logger)
+
+	if c.username != "" && c.password != "" {
+		// The token exchange itself must not go through c.http: that client
+		// carries the OnBeforeRequest hook below, which would call back into
+		// validToken and deadlock on tokenManager.mu (a plain, non-reentrant
+		// sync.Mutex). authHTTP is a plain client with the same timeout and
+		// no auth middleware attached.
+		c.authHTTP = resty.New().SetTimeout(c.http.GetClient().Timeout).SetLogger(c.logger)
+
+		c.tokenMgr = newTokenManager(c)
+		c.http.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+			token, err := c.tokenMgr.validToken(req.Context())
+			if err != nil {
+				return err
+			}
+			req.SetHeader("Authorization", "Token "+token)
+			return nil
+		})
+	} else if c.apiKey != "" {
+		c.http.SetHeader("Authorization", "Token "+c.apiKey)
+	}
+
+	c.Collection = &CollectionService{client: c}
+	c.Disbursement = &DisbursementService{client: c}
+	c.Transaction = &TransactionService{client: c}
+	c.Balance = &BalanceService{client: c}
+
+	return c
+}
+
+// newRequest builds a resty request scoped to this client's HTTP client.
+func (c *Client) newRequest() *resty.Request {
+	return c.http.R()
+}
+
+// url joins the client's base URL with the given path.
+func (c *Client) url(path string) string {
+	return c.baseURL + path
+}
+
+// do executes req against path and decodes a successful response into
+// result. Non-2xx responses are returned as *APIError.
+func (c *Client) do(req *resty.Request, method, path string, result interface{}) error {
+	if result != nil {
+		req.SetResult(result)
+	}
+
+	resp, err := req.Execute(method, c.url(path))
+	if err != nil {
+		return fmt.Errorf("campay: request failed: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusUnauthorized && c.tokenMgr != nil {
+		if _, refreshErr := c.tokenMgr.forceRefresh(req.Context()); refreshErr == nil {
+			resp, err = req.Execute(method, c.url(path))
+			if err != nil {
+				return fmt.Errorf("campay: request failed: %w", err)
+			}
+		}
+	}
+
+	if resp.IsError() {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
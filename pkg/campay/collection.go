@@ -0,0 +1,58 @@
+package campay
+
+import "context"
+
+// CollectionService initiates and tracks mobile money collection
+// (cash-in) requests.
+type CollectionService struct {
+	client *Client
+}
+
+// CollectionRequest describes a payment collection request.
+type CollectionRequest struct {
+	Amount      string `json:"amount"`
+	From        string `json:"from"`
+	Description string `json:"description"`
+}
+
+// CollectionResponse is returned when a collection request is initiated.
+type CollectionResponse struct {
+	Reference string `json:"reference"`
+	Status    string `json:"status"`
+	Message   string `json:"message"`
+}
+
+// CollectionStatus is returned by Status and reflects the current state of
+// a previously initiated collection.
+type CollectionStatus struct {
+	Reference string `json:"reference"`
+	Status    string `json:"status"`
+}
+
+// Initiate starts a new collection request and returns its reference.
+func (s *CollectionService) Initiate(ctx context.Context, req *CollectionRequest) (*CollectionResponse, error) {
+	var resp CollectionResponse
+
+	err := s.client.do(s.client.newRequest().SetContext(ctx).SetBody(req), "POST", "/collect/", &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Reference == "" {
+		return nil, &APIError{Message: "no reference returned: " + resp.Message}
+	}
+
+	return &resp, nil
+}
+
+// Status fetches the current status of a collection by its reference.
+func (s *CollectionService) Status(ctx context.Context, reference string) (*CollectionStatus, error) {
+	var status CollectionStatus
+
+	err := s.client.do(s.client.newRequest().SetContext(ctx), "GET", "/transaction/"+reference+"/", &status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
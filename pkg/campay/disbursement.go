@@ -0,0 +1,39 @@
+package campay
+
+import "context"
+
+// DisbursementService sends mobile money payouts (cash-out).
+type DisbursementService struct {
+	client *Client
+}
+
+// DisbursementRequest describes a payout to a mobile money account.
+type DisbursementRequest struct {
+	Amount            string `json:"amount"`
+	To                string `json:"to"`
+	Description       string `json:"description"`
+	ExternalReference string `json:"external_reference,omitempty"`
+}
+
+// DisbursementResponse is returned when a disbursement is submitted.
+type DisbursementResponse struct {
+	Reference string `json:"reference"`
+	Status    string `json:"status"`
+	Message   string `json:"message"`
+}
+
+// Send submits a disbursement request and returns its reference.
+func (s *DisbursementService) Send(ctx context.Context, req *DisbursementRequest) (*DisbursementResponse, error) {
+	var resp DisbursementResponse
+
+	err := s.client.do(s.client.newRequest().SetContext(ctx).SetBody(req), "POST", "/withdraw/", &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Reference == "" {
+		return nil, &APIError{Message: "no reference returned: " + resp.Message}
+	}
+
+	return &resp, nil
+}
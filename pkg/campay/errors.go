@@ -0,0 +1,50 @@
+package campay
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// APIError represents a non-2xx response from the Campay API.
+type APIError struct {
+	// StatusCode is the HTTP status code returned by the API.
+	StatusCode int
+	// Code is the API-specific error code, if the response included one.
+	Code string
+	// Message is the human-readable error message from the API, if any.
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("campay: api error (status %d, code %s): %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("campay: api error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// IsAPIError reports whether err is an *APIError and returns it.
+func IsAPIError(err error) (*APIError, bool) {
+	apiErr, ok := err.(*APIError)
+	return apiErr, ok
+}
+
+// apiErrorBody is the shape of a Campay error response, e.g.
+// {"code": "invalid_amount", "message": "Amount must be positive"}.
+type apiErrorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// newAPIError builds an *APIError from a non-2xx resty response, parsing
+// out the API's code/message if the body is in the expected shape and
+// falling back to the raw body as the message otherwise.
+func newAPIError(resp *resty.Response) *APIError {
+	var body apiErrorBody
+	if err := json.Unmarshal(resp.Body(), &body); err == nil && body.Message != "" {
+		return &APIError{StatusCode: resp.StatusCode(), Code: body.Code, Message: body.Message}
+	}
+
+	return &APIError{StatusCode: resp.StatusCode(), Message: resp.String()}
+}
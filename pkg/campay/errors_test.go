@@ -0,0 +1,55 @@
+package campay
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDoParsesAPIErrorCode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"code":"invalid_amount","message":"Amount must be positive"}`)
+	}))
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL))
+
+	_, err := c.Balance.Get(context.Background())
+
+	apiErr, ok := IsAPIError(err)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.Code != "invalid_amount" {
+		t.Fatalf("expected code %q, got %q", "invalid_amount", apiErr.Code)
+	}
+	if apiErr.Message != "Amount must be positive" {
+		t.Fatalf("expected message %q, got %q", "Amount must be positive", apiErr.Message)
+	}
+}
+
+func TestDoFallsBackToRawBodyWithoutCode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, "internal server error")
+	}))
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL))
+
+	_, err := c.Balance.Get(context.Background())
+
+	apiErr, ok := IsAPIError(err)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.Code != "" {
+		t.Fatalf("expected empty code, got %q", apiErr.Code)
+	}
+	if apiErr.Message != "internal server error" {
+		t.Fatalf("expected message %q, got %q", "internal server error", apiErr.Message)
+	}
+}
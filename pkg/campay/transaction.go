@@ -0,0 +1,62 @@
+package campay
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// TransactionService looks up historical transactions.
+type TransactionService struct {
+	client *Client
+}
+
+// TransactionFilter narrows down the results returned by History. Zero
+// values are omitted from the request.
+type TransactionFilter struct {
+	Status string
+	Start  string
+	End    string
+	Page   int
+}
+
+// Transaction is a single entry returned by History.
+type Transaction struct {
+	Reference         string `json:"reference"`
+	Status            string `json:"status"`
+	Amount            string `json:"amount"`
+	Operator          string `json:"operator"`
+	ExternalReference string `json:"external_reference"`
+	CreatedAt         string `json:"created_at"`
+}
+
+// History returns transactions matching the given filter.
+func (s *TransactionService) History(ctx context.Context, filter TransactionFilter) ([]Transaction, error) {
+	var transactions []Transaction
+
+	req := s.client.newRequest().SetContext(ctx).SetQueryParamsFromValues(filter.values())
+
+	err := s.client.do(req, "GET", "/transaction/history/", &transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+func (f TransactionFilter) values() url.Values {
+	values := url.Values{}
+	if f.Status != "" {
+		values.Set("status", f.Status)
+	}
+	if f.Start != "" {
+		values.Set("start_date", f.Start)
+	}
+	if f.End != "" {
+		values.Set("end_date", f.End)
+	}
+	if f.Page > 0 {
+		values.Set("page", strconv.Itoa(f.Page))
+	}
+	return values
+}
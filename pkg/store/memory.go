@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is an in-memory TransactionStore. It does not survive
+// process restarts and is intended for local development and tests.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	byRef map[string]Transaction
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{byRef: make(map[string]Transaction)}
+}
+
+// Save inserts or overwrites the transaction identified by txn.Reference.
+func (s *MemoryStore) Save(_ context.Context, txn Transaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.byRef[txn.Reference] = txn
+	return nil
+}
+
+// Get returns the transaction with the given reference, or ErrNotFound.
+func (s *MemoryStore) Get(_ context.Context, reference string) (Transaction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	txn, ok := s.byRef[reference]
+	if !ok {
+		return Transaction{}, ErrNotFound
+	}
+	return txn, nil
+}
+
+// List returns transactions matching filter.
+func (s *MemoryStore) List(_ context.Context, filter Filter) ([]Transaction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var results []Transaction
+	for _, txn := range s.byRef {
+		if filter.Status != "" && txn.Status != filter.Status {
+			continue
+		}
+		if !filter.CreatedBefore.IsZero() && !txn.CreatedAt.Before(filter.CreatedBefore) {
+			continue
+		}
+		results = append(results, txn)
+	}
+	return results, nil
+}
+
+// UpdateStatus transitions the transaction identified by reference to
+// status, or returns ErrNotFound if it hasn't been saved.
+func (s *MemoryStore) UpdateStatus(_ context.Context, reference, status string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	txn, ok := s.byRef[reference]
+	if !ok {
+		return ErrNotFound
+	}
+
+	txn.Status = status
+	txn.UpdatedAt = time.Now().UTC()
+	s.byRef[reference] = txn
+	return nil
+}
@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreSaveAndGet(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+
+	txn := Transaction{Reference: "ref-1", Status: "PENDING", Amount: "1000", Phone: "670000000"}
+	if err := s.Save(ctx, txn); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := s.Get(ctx, "ref-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status != "PENDING" {
+		t.Fatalf("expected status PENDING, got %s", got.Status)
+	}
+}
+
+func TestMemoryStoreGetNotFound(t *testing.T) {
+	s := NewMemoryStore()
+
+	_, err := s.Get(context.Background(), "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestMemoryStoreUpdateStatus(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+
+	if err := s.Save(ctx, Transaction{Reference: "ref-2", Status: "PENDING"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := s.UpdateStatus(ctx, "ref-2", "SUCCESSFUL"); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+
+	got, err := s.Get(ctx, "ref-2")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status != "SUCCESSFUL" {
+		t.Fatalf("expected status SUCCESSFUL, got %s", got.Status)
+	}
+
+	if err := s.UpdateStatus(ctx, "missing", "SUCCESSFUL"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestMemoryStoreListFiltersByStatusAndAge(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+
+	old := time.Now().Add(-time.Hour)
+	recent := time.Now()
+
+	_ = s.Save(ctx, Transaction{Reference: "stale", Status: "PENDING", CreatedAt: old})
+	_ = s.Save(ctx, Transaction{Reference: "fresh", Status: "PENDING", CreatedAt: recent})
+	_ = s.Save(ctx, Transaction{Reference: "done", Status: "SUCCESSFUL", CreatedAt: old})
+
+	results, err := s.List(ctx, Filter{Status: "PENDING", CreatedBefore: time.Now().Add(-time.Minute)})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(results) != 1 || results[0].Reference != "stale" {
+		t.Fatalf("expected only the stale pending transaction, got %+v", results)
+	}
+}
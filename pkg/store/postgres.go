@@ -0,0 +1,20 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/lib/pq"
+)
+
+// NewPostgresStore opens (and does not migrate) a Postgres-backed
+// TransactionStore at dataSourceName. Apply the SQL files under
+// migrations/postgres before first use.
+func NewPostgresStore(dataSourceName string) (*SQLStore, error) {
+	db, err := sql.Open("postgres", dataSourceName)
+	if err != nil {
+		return nil, fmt.Errorf("store: open postgres: %w", err)
+	}
+
+	return &SQLStore{db: db, dialect: dialectPostgres}, nil
+}
@@ -0,0 +1,158 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dialect captures the small differences between the SQL backends this
+// package supports: how bind parameters are written.
+type dialect int
+
+const (
+	dialectSQLite dialect = iota
+	dialectPostgres
+)
+
+// rebind rewrites a query written with "?" placeholders into the form the
+// dialect expects, e.g. "$1" for Postgres.
+func (d dialect) rebind(query string) string {
+	if d != dialectPostgres {
+		return query
+	}
+
+	var sb strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			fmt.Fprintf(&sb, "$%d", n)
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// SQLStore is a TransactionStore backed by database/sql. Use
+// NewSQLiteStore or NewPostgresStore to construct one; both share this
+// implementation since the schema and queries are identical across
+// backends bar bind-parameter syntax.
+type SQLStore struct {
+	db      *sql.DB
+	dialect dialect
+}
+
+// Save inserts the transaction, or updates its status and updated_at if a
+// row with the same reference already exists.
+func (s *SQLStore) Save(ctx context.Context, txn Transaction) error {
+	query := s.dialect.rebind(`
+		INSERT INTO transactions (reference, status, amount, phone, description, external_reference, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (reference) DO UPDATE SET status = excluded.status, updated_at = excluded.updated_at`)
+
+	_, err := s.db.ExecContext(ctx, query,
+		txn.Reference, txn.Status, txn.Amount, txn.Phone, txn.Description,
+		txn.ExternalReference, txn.CreatedAt, txn.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("store: save %s: %w", txn.Reference, err)
+	}
+	return nil
+}
+
+// Get returns the transaction with the given reference, or ErrNotFound.
+func (s *SQLStore) Get(ctx context.Context, reference string) (Transaction, error) {
+	query := s.dialect.rebind(`
+		SELECT reference, status, amount, phone, description, external_reference, created_at, updated_at
+		FROM transactions WHERE reference = ?`)
+
+	var txn Transaction
+	var externalRef sql.NullString
+
+	err := s.db.QueryRowContext(ctx, query, reference).Scan(
+		&txn.Reference, &txn.Status, &txn.Amount, &txn.Phone, &txn.Description,
+		&externalRef, &txn.CreatedAt, &txn.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Transaction{}, ErrNotFound
+	}
+	if err != nil {
+		return Transaction{}, fmt.Errorf("store: get %s: %w", reference, err)
+	}
+
+	txn.ExternalReference = externalRef.String
+	return txn, nil
+}
+
+// List returns transactions matching filter.
+func (s *SQLStore) List(ctx context.Context, filter Filter) ([]Transaction, error) {
+	query := `SELECT reference, status, amount, phone, description, external_reference, created_at, updated_at FROM transactions`
+
+	var conditions []string
+	var args []interface{}
+
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, filter.CreatedBefore)
+	}
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.dialect.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("store: list: %w", err)
+	}
+	defer rows.Close()
+
+	var results []Transaction
+	for rows.Next() {
+		var txn Transaction
+		var externalRef sql.NullString
+
+		if err := rows.Scan(&txn.Reference, &txn.Status, &txn.Amount, &txn.Phone, &txn.Description,
+			&externalRef, &txn.CreatedAt, &txn.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("store: list: scan: %w", err)
+		}
+		txn.ExternalReference = externalRef.String
+		results = append(results, txn)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: list: %w", err)
+	}
+
+	return results, nil
+}
+
+// UpdateStatus transitions the transaction identified by reference to
+// status, or returns ErrNotFound if no row matches.
+func (s *SQLStore) UpdateStatus(ctx context.Context, reference, status string) error {
+	query := s.dialect.rebind(`UPDATE transactions SET status = ?, updated_at = ? WHERE reference = ?`)
+
+	res, err := s.db.ExecContext(ctx, query, status, time.Now().UTC(), reference)
+	if err != nil {
+		return fmt.Errorf("store: update status %s: %w", reference, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("store: update status %s: %w", reference, err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Close releases the underlying database connection.
+func (s *SQLStore) Close() error {
+	return s.db.Close()
+}
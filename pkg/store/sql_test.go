@@ -0,0 +1,155 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestSQLiteStore opens a SQLStore backed by a fresh on-disk SQLite
+// database and applies the checked-in migration, so these tests exercise
+// the real bind-parameter rewriting and SQL the backend ships with.
+func newTestSQLiteStore(t *testing.T) *SQLStore {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "test.db")
+	s, err := NewSQLiteStore(dsn)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	migration, err := os.ReadFile(filepath.Join("migrations", "sqlite", "0001_init.sql"))
+	if err != nil {
+		t.Fatalf("failed to read migration: %v", err)
+	}
+	if _, err := s.db.Exec(string(migration)); err != nil {
+		t.Fatalf("failed to apply migration: %v", err)
+	}
+
+	return s
+}
+
+func TestSQLStoreSaveAndGet(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLiteStore(t)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	txn := Transaction{
+		Reference:         "ref-1",
+		Status:            "PENDING",
+		Amount:            "1000",
+		Phone:             "670000000",
+		Description:       "salary",
+		ExternalReference: "ext-1",
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := s.Save(ctx, txn); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := s.Get(ctx, "ref-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Reference != txn.Reference || got.Status != txn.Status || got.Amount != txn.Amount ||
+		got.Phone != txn.Phone || got.Description != txn.Description || got.ExternalReference != txn.ExternalReference {
+		t.Fatalf("expected %+v, got %+v", txn, got)
+	}
+	if !got.CreatedAt.Equal(now) || !got.UpdatedAt.Equal(now) {
+		t.Fatalf("expected timestamps %v, got created=%v updated=%v", now, got.CreatedAt, got.UpdatedAt)
+	}
+}
+
+func TestSQLStoreSaveUpserts(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLiteStore(t)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	txn := Transaction{Reference: "ref-2", Status: "PENDING", Amount: "1000", Phone: "670000000", Description: "salary", CreatedAt: now, UpdatedAt: now}
+	if err := s.Save(ctx, txn); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	later := now.Add(time.Minute)
+	txn.Status = "SUCCESSFUL"
+	txn.UpdatedAt = later
+	if err := s.Save(ctx, txn); err != nil {
+		t.Fatalf("Save (upsert): %v", err)
+	}
+
+	got, err := s.Get(ctx, "ref-2")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status != "SUCCESSFUL" {
+		t.Fatalf("expected status SUCCESSFUL after upsert, got %s", got.Status)
+	}
+	if !got.UpdatedAt.Equal(later) {
+		t.Fatalf("expected updated_at %v, got %v", later, got.UpdatedAt)
+	}
+}
+
+func TestSQLStoreGetNotFound(t *testing.T) {
+	s := newTestSQLiteStore(t)
+
+	_, err := s.Get(context.Background(), "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestSQLStoreListFiltersByStatusAndAge(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLiteStore(t)
+
+	old := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	recent := time.Now().UTC().Truncate(time.Second)
+
+	mustSave(t, s, Transaction{Reference: "stale", Status: "PENDING", Amount: "1", Phone: "6", Description: "d", CreatedAt: old, UpdatedAt: old})
+	mustSave(t, s, Transaction{Reference: "fresh", Status: "PENDING", Amount: "1", Phone: "6", Description: "d", CreatedAt: recent, UpdatedAt: recent})
+	mustSave(t, s, Transaction{Reference: "done", Status: "SUCCESSFUL", Amount: "1", Phone: "6", Description: "d", CreatedAt: old, UpdatedAt: old})
+
+	results, err := s.List(ctx, Filter{Status: "PENDING", CreatedBefore: time.Now().UTC().Add(-time.Minute)})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(results) != 1 || results[0].Reference != "stale" {
+		t.Fatalf("expected only the stale pending transaction, got %+v", results)
+	}
+}
+
+func TestSQLStoreUpdateStatus(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLiteStore(t)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	mustSave(t, s, Transaction{Reference: "ref-3", Status: "PENDING", Amount: "1", Phone: "6", Description: "d", CreatedAt: now, UpdatedAt: now})
+
+	if err := s.UpdateStatus(ctx, "ref-3", "SUCCESSFUL"); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+
+	got, err := s.Get(ctx, "ref-3")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status != "SUCCESSFUL" {
+		t.Fatalf("expected status SUCCESSFUL, got %s", got.Status)
+	}
+
+	if err := s.UpdateStatus(ctx, "missing", "SUCCESSFUL"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func mustSave(t *testing.T, s *SQLStore, txn Transaction) {
+	t.Helper()
+	if err := s.Save(context.Background(), txn); err != nil {
+		t.Fatalf("Save %s: %v", txn.Reference, err)
+	}
+}
@@ -0,0 +1,20 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "modernc.org/sqlite"
+)
+
+// NewSQLiteStore opens (and does not migrate) a SQLite-backed
+// TransactionStore at dataSourceName. Apply the SQL files under
+// migrations/sqlite before first use.
+func NewSQLiteStore(dataSourceName string) (*SQLStore, error) {
+	db, err := sql.Open("sqlite", dataSourceName)
+	if err != nil {
+		return nil, fmt.Errorf("store: open sqlite: %w", err)
+	}
+
+	return &SQLStore{db: db, dialect: dialectSQLite}, nil
+}
@@ -0,0 +1,44 @@
+// Package store persists Campay transactions so callers get an audit
+// trail and can resume polling across process restarts.
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned when a lookup finds no matching transaction.
+var ErrNotFound = errors.New("store: transaction not found")
+
+// Transaction is a persisted record of a collection or disbursement
+// request, tracked from initiation through its final status.
+type Transaction struct {
+	Reference         string
+	Status            string
+	Amount            string
+	Phone             string
+	Description       string
+	ExternalReference string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// Filter narrows down the results returned by List. Zero values are
+// ignored.
+type Filter struct {
+	// Status restricts results to transactions in this status.
+	Status string
+	// CreatedBefore restricts results to transactions created before this
+	// time, used by the CLI's --reconcile flag to find stale pending ones.
+	CreatedBefore time.Time
+}
+
+// TransactionStore persists transactions across the lifecycle of a
+// payment: initiation, status transitions, and lookups for reconciliation.
+type TransactionStore interface {
+	Save(ctx context.Context, txn Transaction) error
+	Get(ctx context.Context, reference string) (Transaction, error)
+	List(ctx context.Context, filter Filter) ([]Transaction, error)
+	UpdateStatus(ctx context.Context, reference, status string) error
+}
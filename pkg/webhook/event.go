@@ -0,0 +1,14 @@
+// Package webhook receives Campay transaction status callbacks as an
+// alternative to polling with campay.CollectionService.Status.
+package webhook
+
+// WebhookEvent is the payload Campay posts to a registered callback URL
+// whenever a transaction's status changes.
+type WebhookEvent struct {
+	Reference         string `json:"reference"`
+	Status            string `json:"status"`
+	Amount            string `json:"amount"`
+	Operator          string `json:"operator"`
+	ExternalReference string `json:"external_reference"`
+	Signature         string `json:"signature"`
+}
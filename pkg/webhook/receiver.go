@@ -0,0 +1,162 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// issuer is the expected "iss" claim on a Campay webhook signature.
+const issuer = "campay"
+
+// HandlerFunc processes a verified, deduplicated webhook event.
+type HandlerFunc func(ctx context.Context, event WebhookEvent) error
+
+// Receiver is an http.Handler that accepts Campay webhook deliveries,
+// verifies their signature, deduplicates them by reference, and dispatches
+// them to handlers registered for the event's status.
+type Receiver struct {
+	secret string
+	store  Store
+
+	mu       sync.RWMutex
+	handlers map[string][]HandlerFunc
+
+	// refLocks serializes the Seen -> dispatch -> MarkSeen sequence per
+	// reference, so two genuinely concurrent deliveries of the same event
+	// (Campay's webhook delivery is at-least-once) can't both observe
+	// seen == false and both dispatch.
+	refLocks sync.Map // reference string -> *sync.Mutex
+}
+
+// ReceiverOption configures a Receiver.
+type ReceiverOption func(*Receiver)
+
+// WithStore overrides the default MemoryStore used for deduplication.
+func WithStore(store Store) ReceiverOption {
+	return func(r *Receiver) {
+		r.store = store
+	}
+}
+
+// NewReceiver creates a Receiver that verifies signatures using secret,
+// the app's webhook signing key (Campay's WEBHOOK_KEY).
+func NewReceiver(secret string, opts ...ReceiverOption) *Receiver {
+	r := &Receiver{
+		secret:   secret,
+		store:    NewMemoryStore(),
+		handlers: make(map[string][]HandlerFunc),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Register adds fn to the set of handlers invoked for events whose Status
+// equals status. Multiple handlers may be registered for the same status.
+func (r *Receiver) Register(status string, fn HandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.handlers[status] = append(r.handlers[status], fn)
+}
+
+// ServeHTTP implements http.Handler, accepting a POSTed WebhookEvent.
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var event WebhookEvent
+	if err := json.NewDecoder(req.Body).Decode(&event); err != nil {
+		http.Error(w, fmt.Sprintf("webhook: invalid payload: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if err := r.verify(event); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	ctx := req.Context()
+
+	refLock := r.lockFor(event.Reference)
+	refLock.Lock()
+	defer refLock.Unlock()
+
+	seen, err := r.store.Seen(ctx, event.Reference)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("webhook: store lookup failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if seen {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := r.dispatch(ctx, event); err != nil {
+		http.Error(w, fmt.Sprintf("webhook: handler failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if err := r.store.MarkSeen(ctx, event.Reference); err != nil {
+		http.Error(w, fmt.Sprintf("webhook: store write failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// lockFor returns the mutex guarding reference's Seen -> dispatch ->
+// MarkSeen sequence, creating one if this is the first delivery seen for
+// it.
+func (r *Receiver) lockFor(reference string) *sync.Mutex {
+	mu, _ := r.refLocks.LoadOrStore(reference, &sync.Mutex{})
+	return mu.(*sync.Mutex)
+}
+
+// dispatch runs every handler registered for event.Status, returning the
+// first error encountered.
+func (r *Receiver) dispatch(ctx context.Context, event WebhookEvent) error {
+	r.mu.RLock()
+	handlers := r.handlers[event.Status]
+	r.mu.RUnlock()
+
+	for _, fn := range handlers {
+		if err := fn(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verify checks that event.Signature is a JWT signed with r.secret, whose
+// "iss" claim is issuer, that has not expired, and whose "reference" claim
+// matches the event's reference.
+func (r *Receiver) verify(event WebhookEvent) error {
+	token, err := jwt.Parse(event.Signature, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return []byte(r.secret), nil
+	}, jwt.WithValidMethods([]string{"HS256"}), jwt.WithIssuer(issuer), jwt.WithExpirationRequired())
+	if err != nil {
+		return fmt.Errorf("webhook: invalid signature: %w", err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return fmt.Errorf("webhook: invalid token claims")
+	}
+
+	reference, _ := claims["reference"].(string)
+	if reference != event.Reference {
+		return fmt.Errorf("webhook: signature reference does not match event")
+	}
+
+	return nil
+}
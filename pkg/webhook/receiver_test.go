@@ -0,0 +1,143 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "test-webhook-secret"
+
+func signEvent(t *testing.T, reference string, expiresIn time.Duration) string {
+	t.Helper()
+
+	claims := jwt.MapClaims{
+		"iss":       issuer,
+		"reference": reference,
+		"exp":       time.Now().Add(expiresIn).Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatalf("failed to sign test token: %v", err)
+	}
+
+	return signed
+}
+
+func postEvent(r *Receiver, event WebhookEvent) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(event)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/campay", strings.NewReader(string(body)))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestReceiverDispatchesToRegisteredHandler(t *testing.T) {
+	r := NewReceiver(testSecret)
+
+	var got WebhookEvent
+	r.Register("SUCCESSFUL", func(_ context.Context, event WebhookEvent) error {
+		got = event
+		return nil
+	})
+
+	event := WebhookEvent{
+		Reference: "ref-1",
+		Status:    "SUCCESSFUL",
+		Amount:    "1000",
+		Operator:  "MTN",
+	}
+	event.Signature = signEvent(t, event.Reference, time.Minute)
+
+	rec := postEvent(r, event)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got.Reference != event.Reference {
+		t.Fatalf("handler was not invoked with the expected event, got %+v", got)
+	}
+}
+
+func TestReceiverRejectsInvalidSignature(t *testing.T) {
+	r := NewReceiver(testSecret)
+
+	event := WebhookEvent{Reference: "ref-2", Status: "SUCCESSFUL", Signature: "not-a-jwt"}
+	rec := postEvent(r, event)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+}
+
+func TestReceiverRejectsReferenceMismatch(t *testing.T) {
+	r := NewReceiver(testSecret)
+
+	event := WebhookEvent{Reference: "ref-3", Status: "SUCCESSFUL"}
+	event.Signature = signEvent(t, "different-ref", time.Minute)
+
+	rec := postEvent(r, event)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+}
+
+func TestReceiverDeduplicatesByReference(t *testing.T) {
+	r := NewReceiver(testSecret)
+
+	calls := 0
+	r.Register("SUCCESSFUL", func(_ context.Context, _ WebhookEvent) error {
+		calls++
+		return nil
+	})
+
+	event := WebhookEvent{Reference: "ref-4", Status: "SUCCESSFUL"}
+	event.Signature = signEvent(t, event.Reference, time.Minute)
+
+	postEvent(r, event)
+	postEvent(r, event)
+
+	if calls != 1 {
+		t.Fatalf("expected handler to run once, ran %d times", calls)
+	}
+}
+
+func TestReceiverDeduplicatesConcurrentDeliveries(t *testing.T) {
+	r := NewReceiver(testSecret)
+
+	var calls int32
+	r.Register("SUCCESSFUL", func(_ context.Context, _ WebhookEvent) error {
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+
+	event := WebhookEvent{Reference: "ref-5", Status: "SUCCESSFUL"}
+	event.Signature = signEvent(t, event.Reference, time.Minute)
+
+	const deliveries = 10
+	var wg sync.WaitGroup
+	wg.Add(deliveries)
+	for i := 0; i < deliveries; i++ {
+		go func() {
+			defer wg.Done()
+			postEvent(r, event)
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected handler to run once across concurrent deliveries, ran %d times", got)
+	}
+}
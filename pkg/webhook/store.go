@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"context"
+	"sync"
+)
+
+// Store tracks which event references have already been processed so
+// retried deliveries can be safely ignored. Implementations must be safe
+// for concurrent use.
+type Store interface {
+	// Seen reports whether reference has already been marked processed.
+	Seen(ctx context.Context, reference string) (bool, error)
+	// MarkSeen records reference as processed.
+	MarkSeen(ctx context.Context, reference string) error
+}
+
+// MemoryStore is an in-memory Store. It is the default used by Receiver
+// when no Store is supplied, and is suitable for single-process setups.
+type MemoryStore struct {
+	mu   sync.Mutex
+	seen map[string]struct{}
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{seen: make(map[string]struct{})}
+}
+
+// Seen reports whether reference has already been marked processed.
+func (s *MemoryStore) Seen(_ context.Context, reference string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.seen[reference]
+	return ok, nil
+}
+
+// MarkSeen records reference as processed.
+func (s *MemoryStore) MarkSeen(_ context.Context, reference string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seen[reference] = struct{}{}
+	return nil
+}